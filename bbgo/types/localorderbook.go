@@ -0,0 +1,327 @@
+package types
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// PriceLevel is a single price/volume pair on one side of an order book.
+type PriceLevel struct {
+	Price  float64
+	Volume float64
+}
+
+// DepthSnapshot is the response of a REST depth query, used to seed a LocalOrderBook.
+type DepthSnapshot struct {
+	Symbol       string
+	LastUpdateID int64
+	Bids         []PriceLevel
+	Asks         []PriceLevel
+}
+
+// DepthEvent is a single diff update from a depth stream, as described in
+// Binance's "How to manage a local order book correctly" guide:
+// https://binance-docs.github.io/apidocs/spot/en/#how-to-manage-a-local-order-book-correctly
+type DepthEvent struct {
+	Symbol string
+
+	// FirstUpdateID and FinalUpdateID are "U" and "u" in the depth payload.
+	FirstUpdateID int64
+	FinalUpdateID int64
+
+	// PrevFinalUpdateID is "pu", only present on the futures depth stream.
+	// For spot streams this is left zero and FirstUpdateID is used for the
+	// continuity check instead (U == prevU+1).
+	PrevFinalUpdateID int64
+
+	Bids []PriceLevel
+	Asks []PriceLevel
+}
+
+// DepthSnapshotFetcher fetches a fresh REST depth snapshot, used both for the
+// initial sync and for resyncing after a detected gap.
+type DepthSnapshotFetcher func() (*DepthSnapshot, error)
+
+// ChecksumFunc calculates the exchange-defined checksum of the current best
+// bids/asks so it can be compared against the checksum carried on a depth
+// event (currently only used by some Binance futures streams).
+type ChecksumFunc func(book *LocalOrderBook) uint32
+
+// LocalOrderBook maintains a local replica of an exchange order book by
+// combining a REST depth snapshot with buffered depth diff events, following
+// the documented Binance depth-management algorithm: fetch the snapshot,
+// drop any event with u <= lastUpdateId, verify the first applied event
+// satisfies U <= lastUpdateId+1 <= u, and then require pu == previous u (or
+// U == prevU+1 on spot) for every subsequent event. Any gap triggers a
+// resync from a fresh snapshot.
+type LocalOrderBook struct {
+	mu sync.Mutex
+
+	Symbol string
+
+	// IsFutures switches the continuity check from U == prevU+1 (spot) to
+	// pu == prevU (futures).
+	IsFutures bool
+
+	fetchSnapshot DepthSnapshotFetcher
+	checksumFunc  ChecksumFunc
+
+	ready bool
+	bids  map[float64]float64
+	asks  map[float64]float64
+
+	// syncing is true once a snapshot has been fetched for the current sync
+	// attempt and we're just waiting for buffered events to bracket it, so
+	// EmitEvent knows not to fetch another one for every event that arrives
+	// in the meantime.
+	syncing bool
+
+	lastUpdateID int64
+	buffer       []DepthEvent
+}
+
+// NewLocalOrderBook creates a LocalOrderBook for the given symbol. fetcher is
+// called once to seed the book and again automatically whenever a gap is
+// detected in the incoming diff stream.
+func NewLocalOrderBook(symbol string, fetcher DepthSnapshotFetcher) *LocalOrderBook {
+	return &LocalOrderBook{
+		Symbol:        symbol,
+		fetchSnapshot: fetcher,
+		bids:          make(map[float64]float64),
+		asks:          make(map[float64]float64),
+	}
+}
+
+// SetChecksumFunc installs a checksum hook. When set, EmitEvent will not
+// validate the checksum itself -- callers are expected to compare the result
+// against the checksum carried in the raw payload and call Resync on mismatch.
+func (b *LocalOrderBook) SetChecksumFunc(f ChecksumFunc) {
+	b.checksumFunc = f
+}
+
+// Checksum returns the checksum of the current book state, or 0 if no
+// ChecksumFunc has been configured.
+func (b *LocalOrderBook) Checksum() uint32 {
+	b.mu.Lock()
+	checksumFunc := b.checksumFunc
+	b.mu.Unlock()
+	if checksumFunc == nil {
+		return 0
+	}
+	// called without b.mu held so the func can read the book through
+	// Bids()/Asks(), which take the lock themselves
+	return checksumFunc(b)
+}
+
+// EmitEvent feeds a single depth diff event into the book. Events received
+// before the initial snapshot is loaded are buffered and replayed once the
+// snapshot arrives. A detected gap resyncs the book from a fresh snapshot.
+// Only the first event of a sync attempt triggers a fetchSnapshot call;
+// every event after that just drains the buffer against the snapshot
+// already fetched, so a burst of diff messages never fires more than one
+// REST request.
+func (b *LocalOrderBook) EmitEvent(event DepthEvent) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.ready {
+		b.buffer = append(b.buffer, event)
+		if !b.syncing {
+			return b.fetchAndSeed()
+		}
+		return b.drainBuffer()
+	}
+
+	return b.applyEvent(event)
+}
+
+// Resync forces a fresh snapshot fetch on the next event, discarding the
+// current book state. Callers should invoke this when they detect a gap
+// through an out-of-band signal such as a checksum mismatch.
+func (b *LocalOrderBook) Resync() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.reset()
+}
+
+func (b *LocalOrderBook) reset() {
+	b.ready = false
+	b.syncing = false
+	b.bids = make(map[float64]float64)
+	b.asks = make(map[float64]float64)
+	b.lastUpdateID = 0
+	b.buffer = b.buffer[:0]
+}
+
+// fetchAndSeed fetches a fresh snapshot, seeds the book from it, and drains
+// whatever has been buffered so far against it. Must be called with b.mu
+// held, and only when a sync attempt isn't already underway (!b.syncing).
+func (b *LocalOrderBook) fetchAndSeed() error {
+	snapshot, err := b.fetchSnapshot()
+	if err != nil {
+		return errors.Wrapf(err, "depth snapshot fetch error for %s", b.Symbol)
+	}
+
+	b.bids = make(map[float64]float64)
+	b.asks = make(map[float64]float64)
+	for _, p := range snapshot.Bids {
+		b.bids[p.Price] = p.Volume
+	}
+	for _, p := range snapshot.Asks {
+		b.asks[p.Price] = p.Volume
+	}
+	b.lastUpdateID = snapshot.LastUpdateID
+	b.syncing = true
+
+	return b.drainBuffer()
+}
+
+// drainBuffer applies whatever buffered events bracket and follow the
+// already-fetched snapshot's lastUpdateID, without fetching a new one. Must
+// be called with b.mu held.
+func (b *LocalOrderBook) drainBuffer() error {
+	buffered := b.buffer
+	b.buffer = nil
+
+	firstApplied := false
+	for i, event := range buffered {
+		if event.FinalUpdateID <= b.lastUpdateID {
+			// stale, already covered by the snapshot
+			continue
+		}
+
+		if !firstApplied {
+			if !(event.FirstUpdateID <= b.lastUpdateID+1 && b.lastUpdateID+1 <= event.FinalUpdateID) {
+				// the buffered events don't bracket the snapshot yet, wait for more
+				b.buffer = append(b.buffer, event)
+				continue
+			}
+			firstApplied = true
+		}
+
+		if err := b.applyEventUnsynced(event); err != nil {
+			// gap re-appeared while draining the buffer: keep whatever
+			// hasn't been processed yet and resync from a fresh snapshot
+			b.buffer = append(b.buffer, buffered[i:]...)
+			b.syncing = false
+			return b.fetchAndSeed()
+		}
+	}
+
+	if firstApplied {
+		b.ready = true
+	}
+
+	return nil
+}
+
+// applyEvent validates continuity against the last applied event and, on
+// success, applies it. On a detected gap it resyncs from a fresh snapshot.
+func (b *LocalOrderBook) applyEvent(event DepthEvent) error {
+	if err := b.applyEventUnsynced(event); err != nil {
+		b.reset()
+		b.buffer = append(b.buffer, event)
+		return b.fetchAndSeed()
+	}
+	return nil
+}
+
+func (b *LocalOrderBook) applyEventUnsynced(event DepthEvent) error {
+	if event.FinalUpdateID <= b.lastUpdateID {
+		return nil
+	}
+
+	if b.IsFutures {
+		if event.PrevFinalUpdateID != b.lastUpdateID {
+			return fmt.Errorf("depth gap detected for %s: pu=%d != previous u=%d", b.Symbol, event.PrevFinalUpdateID, b.lastUpdateID)
+		}
+	} else if event.FirstUpdateID != b.lastUpdateID+1 {
+		return fmt.Errorf("depth gap detected for %s: U=%d != previous u+1=%d", b.Symbol, event.FirstUpdateID, b.lastUpdateID+1)
+	}
+
+	applySide(b.bids, event.Bids)
+	applySide(b.asks, event.Asks)
+	b.lastUpdateID = event.FinalUpdateID
+	return nil
+}
+
+func applySide(side map[float64]float64, updates []PriceLevel) {
+	for _, p := range updates {
+		if p.Volume == 0 {
+			delete(side, p.Price)
+			continue
+		}
+		side[p.Price] = p.Volume
+	}
+}
+
+// Bids returns the bid side sorted from the best (highest) price down.
+func (b *LocalOrderBook) Bids() []PriceLevel {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return sortedLevels(b.bids, true)
+}
+
+// Asks returns the ask side sorted from the best (lowest) price up.
+func (b *LocalOrderBook) Asks() []PriceLevel {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return sortedLevels(b.asks, false)
+}
+
+func sortedLevels(side map[float64]float64, desc bool) []PriceLevel {
+	levels := make([]PriceLevel, 0, len(side))
+	for price, volume := range side {
+		levels = append(levels, PriceLevel{Price: price, Volume: volume})
+	}
+	sort.Slice(levels, func(i, j int) bool {
+		if desc {
+			return levels[i].Price > levels[j].Price
+		}
+		return levels[i].Price < levels[j].Price
+	})
+	return levels
+}
+
+// BestBid returns the highest bid and true, or a zero value and false if the
+// book has no bids yet.
+func (b *LocalOrderBook) BestBid() (PriceLevel, bool) {
+	bids := b.Bids()
+	if len(bids) == 0 {
+		return PriceLevel{}, false
+	}
+	return bids[0], true
+}
+
+// BestAsk returns the lowest ask and true, or a zero value and false if the
+// book has no asks yet.
+func (b *LocalOrderBook) BestAsk() (PriceLevel, bool) {
+	asks := b.Asks()
+	if len(asks) == 0 {
+		return PriceLevel{}, false
+	}
+	return asks[0], true
+}
+
+// Spread returns ask - bid and true, or 0 and false if either side is empty.
+func (b *LocalOrderBook) Spread() (float64, bool) {
+	bid, ok := b.BestBid()
+	if !ok {
+		return 0, false
+	}
+	ask, ok := b.BestAsk()
+	if !ok {
+		return 0, false
+	}
+	return ask.Price - bid.Price, true
+}
+
+// Ready reports whether the book has completed its initial snapshot sync.
+func (b *LocalOrderBook) Ready() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.ready
+}
@@ -0,0 +1,78 @@
+package types
+
+import "testing"
+
+func newTestSnapshot(lastUpdateID int64) *DepthSnapshot {
+	return &DepthSnapshot{
+		Symbol:       "BTCUSDT",
+		LastUpdateID: lastUpdateID,
+		Bids:         []PriceLevel{{Price: 100, Volume: 1}},
+		Asks:         []PriceLevel{{Price: 101, Volume: 1}},
+	}
+}
+
+// TestLocalOrderBook_FetchesSnapshotOnceWhileBracketing makes sure a burst of
+// events that arrive before the buffered diffs bracket the snapshot only
+// triggers a single REST fetch, not one per event.
+func TestLocalOrderBook_FetchesSnapshotOnceWhileBracketing(t *testing.T) {
+	fetches := 0
+	book := NewLocalOrderBook("BTCUSDT", func() (*DepthSnapshot, error) {
+		fetches++
+		return newTestSnapshot(100), nil
+	})
+
+	// doesn't bracket lastUpdateID+1 (101) yet -- U=102 > 101
+	if err := book.EmitEvent(DepthEvent{Symbol: "BTCUSDT", FirstUpdateID: 102, FinalUpdateID: 103}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if book.Ready() {
+		t.Fatalf("book should not be ready yet")
+	}
+	if fetches != 1 {
+		t.Fatalf("expected 1 fetch after first event, got %d", fetches)
+	}
+
+	// still doesn't bracket -- should not trigger another fetch
+	if err := book.EmitEvent(DepthEvent{Symbol: "BTCUSDT", FirstUpdateID: 110, FinalUpdateID: 111}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fetches != 1 {
+		t.Fatalf("expected fetch count to stay at 1 while waiting to bracket, got %d", fetches)
+	}
+
+	// brackets lastUpdateID+1 (101) -- U=101 <= 101 <= u=103
+	if err := book.EmitEvent(DepthEvent{Symbol: "BTCUSDT", FirstUpdateID: 101, FinalUpdateID: 103}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !book.Ready() {
+		t.Fatalf("book should be ready after bracketing event")
+	}
+	if fetches != 1 {
+		t.Fatalf("expected fetch count to stay at 1 once ready, got %d", fetches)
+	}
+}
+
+// TestLocalOrderBook_ResyncsOnGap verifies that a detected continuity gap on
+// an already-synced book triggers exactly one fresh snapshot fetch.
+func TestLocalOrderBook_ResyncsOnGap(t *testing.T) {
+	fetches := 0
+	book := NewLocalOrderBook("BTCUSDT", func() (*DepthSnapshot, error) {
+		fetches++
+		return newTestSnapshot(100), nil
+	})
+
+	if err := book.EmitEvent(DepthEvent{Symbol: "BTCUSDT", FirstUpdateID: 101, FinalUpdateID: 103}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !book.Ready() || fetches != 1 {
+		t.Fatalf("expected book ready after 1 fetch, got ready=%v fetches=%d", book.Ready(), fetches)
+	}
+
+	// gap: next event's U should be 104, not 110
+	if err := book.EmitEvent(DepthEvent{Symbol: "BTCUSDT", FirstUpdateID: 110, FinalUpdateID: 111}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fetches != 2 {
+		t.Fatalf("expected gap to trigger a resync fetch, got fetches=%d", fetches)
+	}
+}
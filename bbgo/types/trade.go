@@ -4,6 +4,7 @@ import "time"
 
 type Trade struct {
 	ID          int64
+	Exchange    ExchangeName
 	Price       float64
 	Volume      float64
 	Side        string
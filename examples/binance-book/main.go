@@ -15,6 +15,13 @@ import (
 	"github.com/c9s/bbgo/pkg/types"
 )
 
+// depthSnapshotFetcher adapts an exchange client's REST depth query into a
+// types.DepthSnapshotFetcher, so LocalOrderBook doesn't need to know about
+// exchange-specific clients.
+type depthSnapshotFetcher interface {
+	QueryDepth(ctx context.Context, symbol string) (types.DepthSnapshot, error)
+}
+
 func init() {
 	rootCmd.PersistentFlags().String("binance-api-key", "", "binance api key")
 	rootCmd.PersistentFlags().String("binance-api-secret", "", "binance api secret")
@@ -48,16 +55,40 @@ var rootCmd = &cobra.Command{
 		stream.SetPublicOnly()
 		stream.Subscribe(types.BookChannel, symbol, types.SubscribeOptions{})
 
-		stream.OnBookSnapshot(func(book types.OrderBook) {
-			log.Infof("book snapshot: %+v", book)
-		})
+		fetcher, ok := interface{}(exchange).(depthSnapshotFetcher)
+		if !ok {
+			return errors.New("exchange does not support depth snapshot queries")
+		}
 
-		stream.OnBookUpdate(func(book types.OrderBook) {
-			log.Infof("book update: %+v", book)
+		book := types.NewLocalOrderBook(symbol, func() (*types.DepthSnapshot, error) {
+			snapshot, err := fetcher.QueryDepth(ctx, symbol)
+			if err != nil {
+				return nil, err
+			}
+			return &snapshot, nil
 		})
 
-		streambook := types.NewStreamBook(symbol)
-		streambook.BindStream(stream)
+		stream.OnBookUpdate(func(update types.OrderBook) {
+			event := types.DepthEvent{
+				Symbol:            symbol,
+				FirstUpdateID:     update.FirstUpdateID,
+				FinalUpdateID:     update.FinalUpdateID,
+				PrevFinalUpdateID: update.PrevFinalUpdateID,
+				Bids:              update.Bids,
+				Asks:              update.Asks,
+			}
+
+			if err := book.EmitEvent(event); err != nil {
+				log.WithError(err).Error("depth event error")
+				return
+			}
+
+			bid, hasBid := book.BestBid()
+			ask, hasAsk := book.BestAsk()
+			if hasBid && hasAsk {
+				log.Infof("top of book: bid %f ask %f", bid.Price, ask.Price)
+			}
+		})
 
 		log.Info("connecting websocket...")
 		if err := stream.Connect(ctx); err != nil {
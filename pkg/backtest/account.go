@@ -0,0 +1,99 @@
+package backtest
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+// SimpleAccount tracks simulated balances for the backtest Matching engine.
+// It is intentionally minimal: quote/base balances are moved by the
+// notional value of each fill, fees are ignored, and there is no margin or
+// leverage support.
+type SimpleAccount struct {
+	mu       sync.Mutex
+	balances types.BalanceMap
+}
+
+// NewSimpleAccount creates an empty SimpleAccount. Use SetBalance to seed it
+// with starting capital before running a backtest.
+func NewSimpleAccount() *SimpleAccount {
+	return &SimpleAccount{
+		balances: make(types.BalanceMap),
+	}
+}
+
+// SetBalance seeds or overrides the available balance of a currency.
+func (a *SimpleAccount) SetBalance(currency string, available float64) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.balances[currency] = types.Balance{Currency: currency, Available: available}
+}
+
+// Balances returns a snapshot of the current simulated balances.
+func (a *SimpleAccount) Balances() types.BalanceMap {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	snapshot := make(types.BalanceMap, len(a.balances))
+	for currency, balance := range a.balances {
+		snapshot[currency] = balance
+	}
+	return snapshot
+}
+
+// applyFill moves base/quote balances for a filled order at the given price.
+// Symbols are assumed to be BASEQUOTE concatenated with no separator, e.g.
+// "BTCUSDT", matching how markets are keyed elsewhere in bbgo.
+func (a *SimpleAccount) applyFill(order types.SubmitOrder, price float64) error {
+	base, quote, err := splitSymbol(order.Symbol)
+	if err != nil {
+		return err
+	}
+
+	notional := price * order.Quantity
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	baseBalance := a.balances[base]
+	quoteBalance := a.balances[quote]
+
+	switch order.Side {
+	case types.SideTypeBuy:
+		if quoteBalance.Available < notional {
+			return fmt.Errorf("backtest account: insufficient %s balance: have %f, need %f", quote, quoteBalance.Available, notional)
+		}
+		quoteBalance.Available -= notional
+		baseBalance.Available += order.Quantity
+
+	case types.SideTypeSell:
+		if baseBalance.Available < order.Quantity {
+			return fmt.Errorf("backtest account: insufficient %s balance: have %f, need %f", base, baseBalance.Available, order.Quantity)
+		}
+		baseBalance.Available -= order.Quantity
+		quoteBalance.Available += notional
+
+	default:
+		return fmt.Errorf("backtest account: unsupported order side %q", order.Side)
+	}
+
+	baseBalance.Currency = base
+	quoteBalance.Currency = quote
+	a.balances[base] = baseBalance
+	a.balances[quote] = quoteBalance
+	return nil
+}
+
+// splitSymbol splits a concatenated symbol like "BTCUSDT" into its base and
+// quote currencies using the common stable-coin/major quote suffixes.
+func splitSymbol(symbol string) (base, quote string, err error) {
+	for _, q := range []string{"USDT", "BUSD", "USDC", "BTC", "ETH", "BNB"} {
+		if strings.HasSuffix(symbol, q) && len(symbol) > len(q) {
+			return strings.TrimSuffix(symbol, q), q, nil
+		}
+	}
+	return "", "", fmt.Errorf("backtest account: unable to split symbol %q into base/quote", symbol)
+}
@@ -0,0 +1,116 @@
+// Package backtest provides an offline implementation of types.Exchange that
+// replays historical klines instead of talking to a live exchange, so
+// strategies registered with bbgo.RegisterStrategy can be run unchanged
+// against historical data. Only klines are replayed; historical trades are
+// not.
+package backtest
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/c9s/bbgo/pkg/service"
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+// Exchange is a simulated types.Exchange backed by a replayed Stream and a
+// Matching engine. Orders submitted through SubmitOrder are filled against
+// the replayed klines instead of being sent to a real exchange.
+type Exchange struct {
+	sourceName types.ExchangeName
+
+	startTime, endTime time.Time
+
+	tradeService *service.TradeService
+
+	markets types.MarketMap
+	klines  map[string][]types.KLine
+
+	stream   *Stream
+	matching *Matching
+}
+
+// NewExchange creates a backtest Exchange that replays the given source
+// exchange's historical data between startTime and endTime. klines is keyed
+// by symbol and is passed in already loaded by the caller; this package does
+// not read klines from the TradeService DB or from CSV/parquet files itself.
+func NewExchange(sourceName types.ExchangeName, tradeService *service.TradeService, startTime, endTime time.Time, markets types.MarketMap, klines map[string][]types.KLine) *Exchange {
+	return &Exchange{
+		sourceName:   sourceName,
+		startTime:    startTime,
+		endTime:      endTime,
+		tradeService: tradeService,
+		markets:      markets,
+		klines:       klines,
+	}
+}
+
+func (e *Exchange) Name() types.ExchangeName {
+	return e.sourceName
+}
+
+func (e *Exchange) PlatformFeeCurrency() string {
+	return "BNB"
+}
+
+// NewStream returns the replay stream shared by this exchange. Subsequent
+// calls return the same instance, matching how a real exchange hands out one
+// user-data stream per client. The matching engine's fills are wired back
+// into the stream's trade/order update emitters so SubmitOrder's fills reach
+// session.Trades the same way a real exchange's user-data stream would.
+func (e *Exchange) NewStream() types.Stream {
+	if e.stream == nil {
+		e.stream = NewStream(e.startTime, e.endTime, e.klines)
+		e.matching = NewMatching(e.markets)
+		e.stream.OnKLineClosed(e.matching.processKLine)
+		e.matching.OnTradeUpdate(e.stream.EmitTradeUpdate)
+		e.matching.OnOrderUpdate(e.stream.EmitOrderUpdate)
+	}
+	return e.stream
+}
+
+func (e *Exchange) QueryMarkets(ctx context.Context) (types.MarketMap, error) {
+	return e.markets, nil
+}
+
+func (e *Exchange) QueryAccountBalances(ctx context.Context) (types.BalanceMap, error) {
+	if e.matching == nil {
+		return nil, fmt.Errorf("backtest exchange stream is not initialized, call NewStream first")
+	}
+	return e.matching.Account.Balances(), nil
+}
+
+// QueryKLines filters the preloaded klines for symbol the same way a real
+// exchange client would: by interval, and by options.EndTime/Limit. This
+// matters during Environment.Init, which queries with EndTime pinned to just
+// before the backtest's startTime to seed indicators without looking ahead
+// into data that hasn't "happened" yet in the replay.
+func (e *Exchange) QueryKLines(ctx context.Context, symbol string, interval types.Interval, options types.KLineQueryOptions) ([]types.KLine, error) {
+	var filtered []types.KLine
+	for _, k := range e.klines[symbol] {
+		if k.Interval != interval {
+			continue
+		}
+		if options.EndTime != nil && k.EndTime.After(*options.EndTime) {
+			continue
+		}
+		filtered = append(filtered, k)
+	}
+
+	if options.Limit > 0 && len(filtered) > options.Limit {
+		filtered = filtered[len(filtered)-options.Limit:]
+	}
+
+	return filtered, nil
+}
+
+// SubmitOrder hands the order to the matching engine, which fills it
+// synchronously against the last replayed price and emits the resulting
+// trade/order update through the stream.
+func (e *Exchange) SubmitOrder(ctx context.Context, order types.SubmitOrder) (*types.Order, error) {
+	if e.matching == nil {
+		return nil, fmt.Errorf("backtest exchange stream is not initialized, call NewStream first")
+	}
+	return e.matching.PlaceOrder(order)
+}
@@ -0,0 +1,130 @@
+package backtest
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+// Matching is a simplified matching engine used by the backtest Exchange: it
+// fills market orders against the last replayed kline's close price and
+// limit orders against the limit price (if the kline's high/low range ever
+// reached it) instead of simulating a full order book, and tracks the
+// resulting balances so strategies can query them the same way they would
+// against a live account.
+type Matching struct {
+	Markets types.MarketMap
+	Account *SimpleAccount
+
+	mu         sync.Mutex
+	lastPrices map[string]float64
+	lastKLines map[string]types.KLine
+	orderID    uint64
+	tradeID    uint64
+
+	onTradeUpdate []func(trade types.Trade)
+	onOrderUpdate []func(order types.Order)
+}
+
+// NewMatching creates a Matching engine seeded with a fresh SimpleAccount.
+func NewMatching(markets types.MarketMap) *Matching {
+	return &Matching{
+		Markets:    markets,
+		Account:    NewSimpleAccount(),
+		lastPrices: make(map[string]float64),
+		lastKLines: make(map[string]types.KLine),
+	}
+}
+
+// OnTradeUpdate registers a callback invoked for every simulated fill.
+func (m *Matching) OnTradeUpdate(cb func(trade types.Trade)) {
+	m.onTradeUpdate = append(m.onTradeUpdate, cb)
+}
+
+// OnOrderUpdate registers a callback invoked for every order state change.
+func (m *Matching) OnOrderUpdate(cb func(order types.Order)) {
+	m.onOrderUpdate = append(m.onOrderUpdate, cb)
+}
+
+func (m *Matching) processKLine(k types.KLine) {
+	m.mu.Lock()
+	m.lastPrices[k.Symbol] = k.Close
+	m.lastKLines[k.Symbol] = k
+	m.mu.Unlock()
+}
+
+func (m *Matching) processTrade(trade types.Trade) {
+	m.mu.Lock()
+	m.lastPrices[trade.Symbol] = trade.Price
+	m.mu.Unlock()
+}
+
+// PlaceOrder fills market orders immediately at the last replayed price for
+// their symbol. Limit orders only fill if the last replayed kline's
+// high/low range actually reached the limit price -- otherwise the order is
+// returned unfilled (Status New, ExecutedQuantity 0) with no trade emitted,
+// since this engine has no order book to rest it on. Fills update the
+// simulated account and emit the resulting trade and order update to any
+// registered callbacks.
+func (m *Matching) PlaceOrder(order types.SubmitOrder) (*types.Order, error) {
+	m.mu.Lock()
+	price, ok := m.lastPrices[order.Symbol]
+	k, hasKLine := m.lastKLines[order.Symbol]
+	m.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("backtest matching: no replayed price yet for %s", order.Symbol)
+	}
+
+	if order.Type == types.OrderTypeLimit && order.Price > 0 {
+		if !hasKLine || order.Price < k.Low || order.Price > k.High {
+			return &types.Order{
+				SubmitOrder: order,
+				Status:      types.OrderStatusNew,
+			}, nil
+		}
+		price = order.Price
+	}
+
+	m.mu.Lock()
+	m.orderID++
+	m.tradeID++
+	orderID, tradeID := m.orderID, m.tradeID
+	m.mu.Unlock()
+
+	if err := m.Account.applyFill(order, price); err != nil {
+		return nil, err
+	}
+
+	trade := types.Trade{
+		ID:      int64(tradeID),
+		Price:   price,
+		Volume:  order.Quantity,
+		Side:    string(order.Side),
+		IsMaker: false,
+		Symbol:  order.Symbol,
+	}
+
+	// stamp the actual matched price onto the returned order -- market
+	// orders arrive with Price unset, so callers relying on the embedded
+	// SubmitOrder.Price to know what they paid (e.g. HedgedExecutor's
+	// slippage tracking) would otherwise always see 0.
+	filledOrder := order
+	filledOrder.Price = price
+
+	result := types.Order{
+		SubmitOrder:      filledOrder,
+		OrderID:          orderID,
+		Status:           types.OrderStatusFilled,
+		ExecutedQuantity: order.Quantity,
+	}
+
+	for _, cb := range m.onTradeUpdate {
+		cb(trade)
+	}
+	for _, cb := range m.onOrderUpdate {
+		cb(result)
+	}
+
+	return &result, nil
+}
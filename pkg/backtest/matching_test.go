@@ -0,0 +1,92 @@
+package backtest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+func newTestExchange() (*Exchange, types.KLine) {
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2021, 1, 1, 1, 0, 0, 0, time.UTC)
+	kline := types.KLine{
+		Symbol:    "BTCUSDT",
+		Interval:  types.Interval1m,
+		StartTime: start,
+		EndTime:   start.Add(time.Minute),
+		Open:      100,
+		High:      110,
+		Low:       90,
+		Close:     100,
+	}
+	markets := types.MarketMap{
+		"BTCUSDT": types.Market{Symbol: "BTCUSDT", BaseCurrency: "BTC", QuoteCurrency: "USDT"},
+	}
+	klines := map[string][]types.KLine{"BTCUSDT": {kline}}
+
+	return NewExchange("backtest", nil, start, end, markets, klines), kline
+}
+
+// TestExchange_SubmitOrderFillReachesStream exercises SubmitOrder -> Matching
+// -> Stream end to end, guarding against the fill never leaving the matching
+// engine (Matching.OnTradeUpdate/OnOrderUpdate wired to nothing).
+func TestExchange_SubmitOrderFillReachesStream(t *testing.T) {
+	ex, _ := newTestExchange()
+	stream := ex.NewStream()
+
+	var trades []types.Trade
+	var orders []types.Order
+	stream.OnTradeUpdate(func(trade types.Trade) { trades = append(trades, trade) })
+	stream.OnOrderUpdate(func(order types.Order) { orders = append(orders, order) })
+
+	ctx := context.Background()
+	if err := stream.Connect(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ex.matching.Account.SetBalance("USDT", 1000)
+
+	order := types.SubmitOrder{Symbol: "BTCUSDT", Side: types.SideTypeBuy, Type: types.OrderTypeMarket, Quantity: 1}
+	if _, err := ex.SubmitOrder(ctx, order); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(trades) != 1 {
+		t.Fatalf("expected 1 trade to reach the stream, got %d", len(trades))
+	}
+	if len(orders) != 1 {
+		t.Fatalf("expected 1 order update to reach the stream, got %d", len(orders))
+	}
+}
+
+// TestMatching_LimitOrderOnlyFillsWithinKLineRange guards against limit
+// orders filling at their requested price regardless of whether the
+// replayed kline's range ever reached it.
+func TestMatching_LimitOrderOnlyFillsWithinKLineRange(t *testing.T) {
+	ex, kline := newTestExchange()
+	m := NewMatching(ex.markets)
+	m.processKLine(kline)
+	m.Account.SetBalance("USDT", 1000)
+
+	// kline range is [90, 110]; 200 was never reached, so this should not fill.
+	unreachable := types.SubmitOrder{Symbol: "BTCUSDT", Side: types.SideTypeBuy, Type: types.OrderTypeLimit, Quantity: 1, Price: 200}
+	result, err := m.PlaceOrder(unreachable)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Status != types.OrderStatusNew || result.ExecutedQuantity != 0 {
+		t.Fatalf("expected unreached limit price to stay unfilled, got status=%v executed=%v", result.Status, result.ExecutedQuantity)
+	}
+
+	// 105 is within [90, 110], so this should fill at the limit price.
+	reachable := types.SubmitOrder{Symbol: "BTCUSDT", Side: types.SideTypeBuy, Type: types.OrderTypeLimit, Quantity: 1, Price: 105}
+	result, err = m.PlaceOrder(reachable)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Status != types.OrderStatusFilled || result.Price != 105 {
+		t.Fatalf("expected reachable limit price to fill at 105, got status=%v price=%v", result.Status, result.Price)
+	}
+}
@@ -0,0 +1,75 @@
+package backtest
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+// Stream replays preloaded klines in chronological order instead of
+// connecting to a real exchange websocket. Connect blocks until the replay
+// reaches endTime (or ctx is canceled), so the caller's Environment.Connect
+// loop drives the whole backtest synchronously.
+type Stream struct {
+	types.StandardStream
+
+	startTime, endTime time.Time
+	klines             []types.KLine
+}
+
+// NewStream creates a replay Stream over the given per-symbol klines,
+// flattened and sorted into a single chronological timeline.
+func NewStream(startTime, endTime time.Time, klinesBySymbol map[string][]types.KLine) *Stream {
+	var klines []types.KLine
+	for _, ks := range klinesBySymbol {
+		klines = append(klines, ks...)
+	}
+	sort.Slice(klines, func(i, j int) bool {
+		return klines[i].StartTime.Before(klines[j].StartTime)
+	})
+
+	return &Stream{
+		startTime: startTime,
+		endTime:   endTime,
+		klines:    klines,
+	}
+}
+
+func (s *Stream) SetPublicOnly() {}
+
+func (s *Stream) Subscribe(channel types.Channel, symbol string, options types.SubscribeOptions) {
+	// the backtest stream replays every preloaded kline regardless of
+	// subscription, so there is nothing to record here.
+}
+
+// Connect replays the preloaded klines in order, emitting OnKLineClosed for
+// each one. The matching engine hooked up by Exchange.NewStream only records
+// each kline's prices; OnTradeUpdate/OnOrderUpdate events are emitted on
+// this stream when an order submitted through Exchange.SubmitOrder fills.
+func (s *Stream) Connect(ctx context.Context) error {
+	log.Infof("replaying %d klines from %s to %s", len(s.klines), s.startTime, s.endTime)
+
+	for _, k := range s.klines {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		if k.StartTime.Before(s.startTime) || k.StartTime.After(s.endTime) {
+			continue
+		}
+
+		s.EmitKLineClosed(k)
+	}
+
+	return nil
+}
+
+func (s *Stream) Close() error {
+	return nil
+}
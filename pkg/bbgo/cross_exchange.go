@@ -0,0 +1,95 @@
+package bbgo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+// CrossExchangeStrategy is implemented by strategies that trade across a
+// pair of ExchangeSessions at once, such as cross-exchange arbitrage, as
+// opposed to SingleExchangeStrategy which only ever sees one session.
+type CrossExchangeStrategy interface {
+	CrossRun(ctx context.Context, session *CrossExchangeSession, executor *HedgedExecutor) error
+}
+
+// CrossExchangeSession pairs two ExchangeSessions together and keeps a
+// synchronized top-of-book snapshot derived from both sides' StreamBooks, so
+// a CrossExchangeStrategy can compare prices across venues without polling
+// each session independently.
+type CrossExchangeSession struct {
+	A, B *ExchangeSession
+
+	SymbolA, SymbolB string
+
+	bookA *types.StreamBook
+	bookB *types.StreamBook
+}
+
+// NewCrossExchangeSession pairs sessionA/symbolA with sessionB/symbolB and
+// binds to both sides' book streams so BestBidAskA/BestBidAskB always reflect
+// the latest snapshot from each venue.
+func NewCrossExchangeSession(sessionA *ExchangeSession, symbolA string, sessionB *ExchangeSession, symbolB string) *CrossExchangeSession {
+	cs := &CrossExchangeSession{
+		A:       sessionA,
+		B:       sessionB,
+		SymbolA: symbolA,
+		SymbolB: symbolB,
+		bookA:   types.NewStreamBook(symbolA),
+		bookB:   types.NewStreamBook(symbolB),
+	}
+
+	cs.bookA.BindStream(sessionA.Stream)
+	cs.bookB.BindStream(sessionB.Stream)
+
+	return cs
+}
+
+// BestBidAskA returns the current best bid/ask on session A's book.
+func (cs *CrossExchangeSession) BestBidAskA() (bid, ask types.PriceVolume, ok bool) {
+	return bestBidAsk(cs.bookA)
+}
+
+// BestBidAskB returns the current best bid/ask on session B's book.
+func (cs *CrossExchangeSession) BestBidAskB() (bid, ask types.PriceVolume, ok bool) {
+	return bestBidAsk(cs.bookB)
+}
+
+func bestBidAsk(book *types.StreamBook) (bid, ask types.PriceVolume, ok bool) {
+	bids := book.Bids()
+	asks := book.Asks()
+	if len(bids) == 0 || len(asks) == 0 {
+		return bid, ask, false
+	}
+	return bids[0], asks[0], true
+}
+
+// NewCrossExchangeSession looks up sessionNameA/sessionNameB in the
+// environment and pairs them for cross-exchange trading.
+func (environ *Environment) NewCrossExchangeSession(sessionNameA, symbolA, sessionNameB, symbolB string) (*CrossExchangeSession, error) {
+	sessionA, ok := environ.sessions[sessionNameA]
+	if !ok {
+		return nil, fmt.Errorf("session %s not found", sessionNameA)
+	}
+
+	sessionB, ok := environ.sessions[sessionNameB]
+	if !ok {
+		return nil, fmt.Errorf("session %s not found", sessionNameB)
+	}
+
+	return NewCrossExchangeSession(sessionA, symbolA, sessionB, symbolB), nil
+}
+
+// RunCrossExchangeStrategies runs every strategy registered through
+// RegisterStrategy as a CrossExchangeStrategy against the given paired
+// session, each with its own HedgedExecutor.
+func (environ *Environment) RunCrossExchangeStrategies(ctx context.Context, cs *CrossExchangeSession) error {
+	for id, strategy := range LoadedCrossExchangeStrategies {
+		executor := NewHedgedExecutor(cs, &environ.Notifiability)
+		if err := strategy.CrossRun(ctx, cs, executor); err != nil {
+			return fmt.Errorf("cross exchange strategy %q error: %w", id, err)
+		}
+	}
+	return nil
+}
@@ -50,26 +50,75 @@ type Environment struct {
 	startTime     time.Time
 	tradeScanTime time.Time
 	sessions      map[string]*ExchangeSession
+
+	// endTime is the end of the window given to NewBacktestEnvironment. It is
+	// the zero value for a live environment. Environment does not read it
+	// back; the replay is bounded by each backtest.Exchange's own end time.
+	endTime time.Time
+
+	// tradeParquetDir holds a directory requested through SyncTradesToParquet
+	// until TradeSync exists, so it can be applied regardless of call order.
+	tradeParquetDir string
+
+	// IsBackTest is true when the environment was created with
+	// NewBacktestEnvironment, so strategies and reports can tell replayed
+	// data apart from a live connection without threading a flag through.
+	IsBackTest bool
 }
 
 func NewEnvironment() *Environment {
 	return &Environment{
+		Notifiability: NewNotifiability(),
+
 		// default trade scan time
 		tradeScanTime: time.Now().AddDate(0, 0, -7), // sync from 7 days ago
 		sessions:      make(map[string]*ExchangeSession),
 	}
 }
 
+// NewBacktestEnvironment creates an Environment configured to run strategies
+// against replayed historical klines between startTime and endTime
+// instead of a live exchange connection. Sessions added with AddExchange
+// should be backed by a *backtest.Exchange; RegisterStrategy'd strategies
+// run unchanged on top of it.
+func NewBacktestEnvironment(startTime, endTime time.Time) *Environment {
+	environ := NewEnvironment()
+	environ.IsBackTest = true
+	environ.startTime = startTime
+	environ.tradeScanTime = startTime
+	environ.endTime = endTime
+	return environ
+}
+
 func (environ *Environment) Sessions() map[string]*ExchangeSession {
 	return environ.sessions
 }
 
 func (environ *Environment) SyncTrades(db *sqlx.DB) *Environment {
-	environ.TradeService = &service.TradeService{DB: db}
+	environ.TradeService = service.NewTradeService(service.NewSQLTradeStore(db))
 	environ.TradeSync = &service.SyncService{
 		TradeService: environ.TradeService,
 	}
 
+	// apply a Parquet directory requested through SyncTradesToParquet before
+	// TradeSync existed, so call order between the two doesn't matter.
+	if environ.tradeParquetDir != "" {
+		environ.TradeSync.ParquetExporter = service.NewParquetTradeExporter(environ.tradeParquetDir)
+	}
+
+	return environ
+}
+
+// SyncTradesToParquet additionally mirrors every synced trade out to Parquet
+// files under directory, so the backtester or external analytics tools can
+// read them without hitting the OLTP database. It can be called before or
+// after SyncTrades.
+func (environ *Environment) SyncTradesToParquet(directory string) *Environment {
+	environ.tradeParquetDir = directory
+	if environ.TradeSync != nil {
+		environ.TradeSync.ParquetExporter = service.NewParquetTradeExporter(directory)
+	}
+
 	return environ
 }
 
@@ -212,6 +261,7 @@ func (environ *Environment) Init(ctx context.Context) (err error) {
 
 		if environ.TradeService != nil {
 			session.Stream.OnTradeUpdate(func(trade types.Trade) {
+				trade.Exchange = session.Exchange.Name()
 				if err := environ.TradeService.Insert(trade); err != nil {
 					log.WithError(err).Errorf("trade insert error: %+v", trade)
 				}
@@ -273,7 +323,7 @@ func (environ *Environment) ConfigureNotification(conf *NotificationConfig) erro
 		case "$session":
 			defaultTradeUpdateHandler := func(trade types.Trade) {
 				text := util.Render(TemplateTradeReport, trade)
-				environ.Notify(text, &trade)
+				environ.dispatch(conf.Routing.TradeNotifier, "", text, &trade)
 			}
 			for name := range environ.sessions {
 				session := environ.sessions[name]
@@ -283,7 +333,7 @@ func (environ *Environment) ConfigureNotification(conf *NotificationConfig) erro
 				if ok {
 					session.Stream.OnTradeUpdate(func(trade types.Trade) {
 						text := util.Render(TemplateTradeReport, trade)
-						environ.NotifyTo(channel, text, &trade)
+						environ.dispatch(conf.Routing.TradeNotifier, channel, text, &trade)
 					})
 				} else {
 					session.Stream.OnTradeUpdate(defaultTradeUpdateHandler)
@@ -304,12 +354,8 @@ func (environ *Environment) ConfigureNotification(conf *NotificationConfig) erro
 			// use same handler for each session
 			handler := func(trade types.Trade) {
 				text := util.Render(TemplateTradeReport, trade)
-				channel, ok := environ.RouteObject(&trade)
-				if ok {
-					environ.NotifyTo(channel, text, &trade)
-				} else {
-					environ.Notify(text, &trade)
-				}
+				channel, _ := environ.RouteObject(&trade)
+				environ.dispatch(conf.Routing.TradeNotifier, channel, text, &trade)
 			}
 			for _, session := range environ.sessions {
 				session.Stream.OnTradeUpdate(handler)
@@ -323,7 +369,7 @@ func (environ *Environment) ConfigureNotification(conf *NotificationConfig) erro
 		case "$session":
 			defaultOrderUpdateHandler := func(order types.Order) {
 				text := util.Render(TemplateOrderReport, order)
-				environ.Notify(text, &order)
+				environ.dispatch(conf.Routing.OrderNotifier, "", text, &order)
 			}
 			for name := range environ.sessions {
 				session := environ.sessions[name]
@@ -333,7 +379,7 @@ func (environ *Environment) ConfigureNotification(conf *NotificationConfig) erro
 				if ok {
 					session.Stream.OnOrderUpdate(func(order types.Order) {
 						text := util.Render(TemplateOrderReport, order)
-						environ.NotifyTo(channel, text, &order)
+						environ.dispatch(conf.Routing.OrderNotifier, channel, text, &order)
 					})
 				} else {
 					session.Stream.OnOrderUpdate(defaultOrderUpdateHandler)
@@ -354,12 +400,8 @@ func (environ *Environment) ConfigureNotification(conf *NotificationConfig) erro
 			// use same handler for each session
 			handler := func(order types.Order) {
 				text := util.Render(TemplateOrderReport, order)
-				channel, ok := environ.RouteObject(&order)
-				if ok {
-					environ.NotifyTo(channel, text, &order)
-				} else {
-					environ.Notify(text, &order)
-				}
+				channel, _ := environ.RouteObject(&order)
+				environ.dispatch(conf.Routing.OrderNotifier, channel, text, &order)
 			}
 			for _, session := range environ.sessions {
 				session.Stream.OnOrderUpdate(handler)
@@ -437,6 +479,17 @@ func (environ *Environment) Connect(ctx context.Context) error {
 	return nil
 }
 
+// PnLReport calculates a per-symbol PnL report for a session's recorded
+// trades using the average-cost method. It is most useful after a backtest
+// run completes, to summarize how a strategy performed against replayed
+// historical data.
+func (environ *Environment) PnLReport(session *ExchangeSession, symbol string) *pnl.AverageCostPnlReport {
+	calculator := &pnl.AverageCostCalculator{
+		TradingFeeCurrency: session.Exchange.PlatformFeeCurrency(),
+	}
+	return calculator.Calculate(symbol, session.Trades[symbol], session.lastPrices[symbol])
+}
+
 func LoadExchangeMarketsWithCache(ctx context.Context, ex types.Exchange) (markets types.MarketMap, err error) {
 	err = WithCache(fmt.Sprintf("%s-markets", ex.Name()), &markets, func() (interface{}, error) {
 		return ex.QueryMarkets(ctx)
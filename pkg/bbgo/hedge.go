@@ -0,0 +1,163 @@
+package bbgo
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+// HedgePosition tracks the net inventory and realized slippage accumulated
+// across both legs of a HedgedExecutor's fills. It is shared across every
+// Hedge call so a strategy can watch its exposure build up (or fail to
+// unwind) over time.
+type HedgePosition struct {
+	mu sync.Mutex
+
+	// BaseA/BaseB are the net base-asset inventory change on each leg;
+	// positive means bought, negative means sold.
+	BaseA, BaseB float64
+
+	// QuoteA/QuoteB are the corresponding quote-asset cash flow, negative
+	// when quote currency was spent.
+	QuoteA, QuoteB float64
+
+	// Slippage accumulates (leg A fill price - reference price) * leg A
+	// executed quantity across every Hedge call, in quote currency.
+	Slippage float64
+}
+
+func (p *HedgePosition) add(leg *legFill, isA bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	signedQty := leg.quantity
+	signedQuote := -leg.price * leg.quantity
+	if leg.side == types.SideTypeSell {
+		signedQty = -signedQty
+		signedQuote = -signedQuote
+	}
+
+	if isA {
+		p.BaseA += signedQty
+		p.QuoteA += signedQuote
+	} else {
+		p.BaseB += signedQty
+		p.QuoteB += signedQuote
+	}
+}
+
+// Snapshot returns a copy of the position so callers can read it without
+// holding the internal lock.
+func (p *HedgePosition) Snapshot() HedgePosition {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return HedgePosition{
+		BaseA: p.BaseA, BaseB: p.BaseB,
+		QuoteA: p.QuoteA, QuoteB: p.QuoteB,
+		Slippage: p.Slippage,
+	}
+}
+
+type legFill struct {
+	side     types.SideType
+	price    float64
+	quantity float64
+}
+
+// HedgedExecutor submits a taker order on one session of a CrossExchangeSession
+// and a paired offsetting order on the other, so an arbitrage strategy can
+// capture a price discrepancy without carrying open exposure on either leg.
+// Partial fills, slippage and net inventory are tracked in a shared
+// HedgePosition and, when Notifiability is set, reported through it.
+type HedgedExecutor struct {
+	Session *CrossExchangeSession
+
+	Notifiability *Notifiability
+
+	Position *HedgePosition
+}
+
+// NewHedgedExecutor creates a HedgedExecutor bound to session, with a fresh
+// HedgePosition and optional notification routing.
+func NewHedgedExecutor(session *CrossExchangeSession, notifiability *Notifiability) *HedgedExecutor {
+	return &HedgedExecutor{
+		Session:       session,
+		Notifiability: notifiability,
+		Position:      &HedgePosition{},
+	}
+}
+
+// Hedge submits a taker order for quantity on session A at referencePrice's
+// side, then submits the offsetting order on session B to flatten the
+// resulting exposure. Both legs' fills update the shared HedgePosition, and
+// the net slippage against referencePrice is reported through Notifiability
+// if one is set.
+func (e *HedgedExecutor) Hedge(ctx context.Context, side types.SideType, quantity, referencePrice float64) error {
+	orderA := types.SubmitOrder{
+		Symbol:   e.Session.SymbolA,
+		Side:     side,
+		Type:     types.OrderTypeMarket,
+		Quantity: quantity,
+	}
+
+	filledA, err := e.Session.A.Exchange.SubmitOrder(ctx, orderA)
+	if err != nil {
+		return fmt.Errorf("hedge leg A order error: %w", err)
+	}
+
+	legA := &legFill{side: side, price: filledA.Price, quantity: filledA.ExecutedQuantity}
+	e.Position.add(legA, true)
+
+	// the offsetting leg trades the opposite side so the two legs net out
+	// to flat inventory once both fill.
+	oppositeSide := types.SideTypeSell
+	if side == types.SideTypeSell {
+		oppositeSide = types.SideTypeBuy
+	}
+
+	orderB := types.SubmitOrder{
+		Symbol:   e.Session.SymbolB,
+		Side:     oppositeSide,
+		Type:     types.OrderTypeMarket,
+		Quantity: filledA.ExecutedQuantity,
+	}
+
+	filledB, err := e.Session.B.Exchange.SubmitOrder(ctx, orderB)
+	if err != nil {
+		// leg A already filled (tracked in Position above), so giving up
+		// silently here would leave the strategy with real, naked exposure
+		// on session A. Retry once before surfacing the failure.
+		filledB, err = e.Session.B.Exchange.SubmitOrder(ctx, orderB)
+	}
+	if err != nil {
+		if e.Notifiability != nil {
+			snapshot := e.Position.Snapshot()
+			text := fmt.Sprintf("hedge leg B failed after leg A filled: %s %s %.8f @ %.8f is now naked exposure on %s (net base A/B %.8f/%.8f): %s",
+				e.Session.SymbolA, side, filledA.ExecutedQuantity, filledA.Price, e.Session.A.Name,
+				snapshot.BaseA, snapshot.BaseB, err)
+			e.Notifiability.Notify(text)
+		}
+		return fmt.Errorf("hedge leg B order error, leg A is unhedged: %w", err)
+	}
+
+	legB := &legFill{side: oppositeSide, price: filledB.Price, quantity: filledB.ExecutedQuantity}
+	e.Position.add(legB, false)
+
+	slippage := (filledA.Price - referencePrice) * filledA.ExecutedQuantity
+	e.Position.mu.Lock()
+	e.Position.Slippage += slippage
+	e.Position.mu.Unlock()
+
+	if e.Notifiability != nil {
+		snapshot := e.Position.Snapshot()
+		text := fmt.Sprintf("hedged %s %s %.8f @ %.8f / %s %s %.8f @ %.8f (slippage %.8f, net base A/B %.8f/%.8f)",
+			e.Session.SymbolA, side, filledA.ExecutedQuantity, filledA.Price,
+			e.Session.SymbolB, oppositeSide, filledB.ExecutedQuantity, filledB.Price,
+			snapshot.Slippage, snapshot.BaseA, snapshot.BaseB)
+		e.Notifiability.Notify(text)
+	}
+
+	return nil
+}
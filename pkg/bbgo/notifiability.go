@@ -0,0 +1,107 @@
+package bbgo
+
+import "bytes"
+
+// Notifiability is embedded into Environment to give it the ability to fan
+// notifications out to one or more Notifier backends, with object-, symbol-
+// and session-based routing resolved through its channel routers.
+type Notifiability struct {
+	SymbolChannelRouter  *PatternChannelRouter
+	SessionChannelRouter *PatternChannelRouter
+	ObjectChannelRouter  *ObjectChannelRouter
+
+	notifiers     []Notifier
+	notifiersByID map[string]Notifier
+}
+
+// NewNotifiability creates a Notifiability with its routers initialized and
+// ready to accept routes and notifiers.
+func NewNotifiability() Notifiability {
+	return Notifiability{
+		SymbolChannelRouter:  NewPatternChannelRouter(),
+		SessionChannelRouter: NewPatternChannelRouter(),
+		ObjectChannelRouter:  NewObjectChannelRouter(),
+		notifiersByID:        make(map[string]Notifier),
+	}
+}
+
+// AddNotifier registers a notifier backend under id (e.g. "slack",
+// "telegram", "discord") so routes can target it specifically through
+// NotifyToNotifier, in addition to receiving fanned-out Notify/NotifyTo
+// calls.
+func (m *Notifiability) AddNotifier(id string, notifier Notifier) {
+	if m.notifiersByID == nil {
+		m.notifiersByID = make(map[string]Notifier)
+	}
+	m.notifiers = append(m.notifiers, notifier)
+	m.notifiersByID[id] = notifier
+}
+
+// Notifier looks up a previously registered notifier by id.
+func (m *Notifiability) Notifier(id string) (Notifier, bool) {
+	notifier, ok := m.notifiersByID[id]
+	return notifier, ok
+}
+
+// RouteObject resolves the channel to notify for obj, using whichever
+// ObjectChannelRouter route matches first.
+func (m *Notifiability) RouteObject(obj interface{}) (channel string, ok bool) {
+	return m.ObjectChannelRouter.RouteObject(obj)
+}
+
+// Notify fans obj out to every registered notifier's default destination.
+func (m *Notifiability) Notify(obj interface{}, args ...interface{}) {
+	for _, n := range m.notifiers {
+		n.Notify(obj, args...)
+	}
+}
+
+// NotifyTo fans obj out to the given channel/chat on every registered
+// notifier.
+func (m *Notifiability) NotifyTo(channel string, obj interface{}, args ...interface{}) {
+	for _, n := range m.notifiers {
+		n.NotifyTo(channel, obj, args...)
+	}
+}
+
+// NotifyToNotifier sends obj through a single named notifier (see
+// AddNotifier), letting a route pin itself to e.g. "telegram" while the rest
+// of the system keeps fanning out to everything registered.
+func (m *Notifiability) NotifyToNotifier(id string, channel string, obj interface{}, args ...interface{}) bool {
+	notifier, ok := m.notifiersByID[id]
+	if !ok {
+		return false
+	}
+	if channel == "" {
+		notifier.Notify(obj, args...)
+	} else {
+		notifier.NotifyTo(channel, obj, args...)
+	}
+	return true
+}
+
+// dispatch sends obj either to a single pinned notifier (if notifierID is
+// set), a routed channel (if channel was resolved), or fanned out to every
+// registered notifier, in that priority order. It backs the per-route
+// notifier selection configured through NotificationRouting.
+func (m *Notifiability) dispatch(notifierID, channel string, obj interface{}, args ...interface{}) {
+	if notifierID != "" && m.NotifyToNotifier(notifierID, channel, obj, args...) {
+		return
+	}
+	if channel != "" {
+		m.NotifyTo(channel, obj, args...)
+		return
+	}
+	m.Notify(obj, args...)
+}
+
+// SendPhoto fans a chart snapshot out to every registered notifier,
+// returning the first error encountered, if any.
+func (m *Notifiability) SendPhoto(buffer *bytes.Buffer) (err error) {
+	for _, n := range m.notifiers {
+		if sendErr := n.SendPhoto(buffer); sendErr != nil && err == nil {
+			err = sendErr
+		}
+	}
+	return err
+}
@@ -0,0 +1,32 @@
+package bbgo
+
+// NotificationRouting configures, per passive object type, whether
+// notifications are silenced ("$silent"), sent through the session-based
+// router ("$session"), or sent through the symbol-based router ("$symbol").
+// TradeNotifier and OrderNotifier optionally pin that route to a single
+// registered notifier (see Notifiability.AddNotifier) instead of fanning out
+// to all of them, e.g. setting TradeNotifier to "telegram" sends trade fills
+// to Telegram only. PnLNotifier is not read by ConfigureNotification, so PnL
+// reports are not pinned to a notifier.
+type NotificationRouting struct {
+	Trade         string `json:"trade,omitempty" yaml:"trade,omitempty"`
+	TradeNotifier string `json:"tradeNotifier,omitempty" yaml:"tradeNotifier,omitempty"`
+
+	Order         string `json:"order,omitempty" yaml:"order,omitempty"`
+	OrderNotifier string `json:"orderNotifier,omitempty" yaml:"orderNotifier,omitempty"`
+
+	SubmitOrder string `json:"submitOrder,omitempty" yaml:"submitOrder,omitempty"`
+
+	PnL         string `json:"pnl,omitempty" yaml:"pnl,omitempty"`
+	PnLNotifier string `json:"pnlNotifier,omitempty" yaml:"pnlNotifier,omitempty"`
+}
+
+// NotificationConfig configures the notification routers and routing rules.
+// It does not list notifier backends; those are registered on
+// Environment.Notifiability with AddNotifier.
+type NotificationConfig struct {
+	SymbolChannels  map[string]string `json:"symbolChannels,omitempty" yaml:"symbolChannels,omitempty"`
+	SessionChannels map[string]string `json:"sessionChannels,omitempty" yaml:"sessionChannels,omitempty"`
+
+	Routing *NotificationRouting `json:"routing,omitempty" yaml:"routing,omitempty"`
+}
@@ -0,0 +1,22 @@
+package bbgo
+
+import "bytes"
+
+// Notifier abstracts a single messenger backend (Slack, Telegram, Discord,
+// ...) so NotificationConfig can fan a message out to more than one of them,
+// and so each route (trade fills, PnL summaries, ...) can pick the backend
+// that fits it best.
+type Notifier interface {
+	// Notify sends obj (usually a rendered string) to the backend's default
+	// destination. args are passed through for backends that render rich
+	// attachments (e.g. Slack) out of the object they describe.
+	Notify(obj interface{}, args ...interface{})
+
+	// NotifyTo sends obj to a specific channel/chat/webhook route understood
+	// by the backend.
+	NotifyTo(channel string, obj interface{}, args ...interface{})
+
+	// SendPhoto delivers a chart snapshot (e.g. from a strategy's PnL chart)
+	// to the backend's default destination.
+	SendPhoto(buffer *bytes.Buffer) error
+}
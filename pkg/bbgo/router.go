@@ -0,0 +1,104 @@
+package bbgo
+
+import (
+	"path/filepath"
+	"sort"
+)
+
+// PatternChannelRouter resolves a name (a session name or a symbol) to a
+// notification channel/chat, matching glob-style patterns such as "BTC*" so
+// one route can cover a family of symbols or sessions.
+type PatternChannelRouter struct {
+	routes map[string]string
+}
+
+// NewPatternChannelRouter creates an empty PatternChannelRouter.
+func NewPatternChannelRouter() *PatternChannelRouter {
+	return &PatternChannelRouter{
+		routes: make(map[string]string),
+	}
+}
+
+// AddRoute merges pattern -> channel routes into the router.
+func (r *PatternChannelRouter) AddRoute(routes map[string]string) {
+	for pattern, channel := range routes {
+		r.routes[pattern] = channel
+	}
+}
+
+// Route finds the channel for name, preferring an exact match before falling
+// back to glob patterns. When more than one pattern matches (e.g. "BTC*"
+// alongside a catch-all "*"), the most specific pattern wins -- specificity
+// is ranked by fewest wildcard characters, then by longest pattern, with the
+// pattern string itself as a final tie-break -- so the result is the same
+// every time instead of depending on Go's randomized map iteration order.
+func (r *PatternChannelRouter) Route(name string) (channel string, ok bool) {
+	if channel, ok = r.routes[name]; ok {
+		return channel, true
+	}
+
+	var matchedPatterns []string
+	for pattern := range r.routes {
+		if matched, _ := filepath.Match(pattern, name); matched {
+			matchedPatterns = append(matchedPatterns, pattern)
+		}
+	}
+	if len(matchedPatterns) == 0 {
+		return "", false
+	}
+
+	sort.Slice(matchedPatterns, func(i, j int) bool {
+		a, b := matchedPatterns[i], matchedPatterns[j]
+		if wa, wb := wildcardCount(a), wildcardCount(b); wa != wb {
+			return wa < wb
+		}
+		if len(a) != len(b) {
+			return len(a) > len(b)
+		}
+		return a < b
+	})
+
+	return r.routes[matchedPatterns[0]], true
+}
+
+func wildcardCount(pattern string) int {
+	count := 0
+	for _, c := range pattern {
+		switch c {
+		case '*', '?', '[', ']':
+			count++
+		}
+	}
+	return count
+}
+
+// ObjectRouteFunc inspects obj and returns the channel it should be routed
+// to, or ok=false if this route doesn't apply to obj.
+type ObjectRouteFunc func(obj interface{}) (channel string, ok bool)
+
+// ObjectChannelRouter routes arbitrary passive objects (types.Trade,
+// types.Order, ...) to a channel by trying each registered route in order.
+type ObjectChannelRouter struct {
+	routes []ObjectRouteFunc
+}
+
+// NewObjectChannelRouter creates an empty ObjectChannelRouter.
+func NewObjectChannelRouter() *ObjectChannelRouter {
+	return &ObjectChannelRouter{}
+}
+
+// Route registers an additional ObjectRouteFunc.
+func (r *ObjectChannelRouter) Route(route ObjectRouteFunc) {
+	r.routes = append(r.routes, route)
+}
+
+// RouteObject resolves the channel for obj by trying each registered route
+// in order and returning the first match.
+func (r *ObjectChannelRouter) RouteObject(obj interface{}) (channel string, ok bool) {
+	for _, route := range r.routes {
+		if channel, ok = route(obj); ok {
+			return channel, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,62 @@
+// Package slacknotifier implements bbgo.Notifier on top of the Slack web
+// API, rendering passive objects (trades, orders, PnL reports) as Slack
+// attachments.
+package slacknotifier
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/nlopes/slack"
+	log "github.com/sirupsen/logrus"
+)
+
+// Notifier sends notifications to a Slack channel using a bot token.
+type Notifier struct {
+	client  *slack.Client
+	channel string
+}
+
+// New creates a Notifier that posts to defaultChannel using client, unless a
+// call overrides the channel with NotifyTo.
+func New(client *slack.Client, defaultChannel string) *Notifier {
+	return &Notifier{
+		client:  client,
+		channel: defaultChannel,
+	}
+}
+
+func (n *Notifier) Notify(obj interface{}, args ...interface{}) {
+	n.NotifyTo(n.channel, obj, args...)
+}
+
+func (n *Notifier) NotifyTo(channel string, obj interface{}, args ...interface{}) {
+	if channel == "" {
+		return
+	}
+
+	text, ok := obj.(string)
+	if !ok {
+		text = fmt.Sprintf("%+v", obj)
+	}
+
+	options := []slack.MsgOption{slack.MsgOptionText(text, true)}
+	for _, arg := range args {
+		if attachment, ok := arg.(slack.Attachment); ok {
+			options = append(options, slack.MsgOptionAttachments(attachment))
+		}
+	}
+
+	if _, _, err := n.client.PostMessage(channel, options...); err != nil {
+		log.WithError(err).Error("slack notify error")
+	}
+}
+
+func (n *Notifier) SendPhoto(buffer *bytes.Buffer) error {
+	_, err := n.client.UploadFile(slack.FileUploadParameters{
+		Channels: []string{n.channel},
+		Reader:   buffer,
+		Filename: "chart.png",
+	})
+	return err
+}
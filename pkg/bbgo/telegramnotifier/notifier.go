@@ -0,0 +1,65 @@
+// Package telegramnotifier implements bbgo.Notifier on top of the Telegram
+// Bot API, so trade/order/PnL notifications can be delivered to a private
+// chat or group alongside (or instead of) Slack.
+package telegramnotifier
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+
+	tgbotapi "github.com/go-telegram-bot-api/telegram-bot-api/v5"
+	log "github.com/sirupsen/logrus"
+)
+
+// Notifier sends notifications through a Telegram bot to a fixed chat ID,
+// unless a call overrides the destination with NotifyTo.
+type Notifier struct {
+	bot    *tgbotapi.BotAPI
+	chatID int64
+}
+
+// New creates a Notifier that delivers to defaultChatID using bot.
+func New(bot *tgbotapi.BotAPI, defaultChatID int64) *Notifier {
+	return &Notifier{
+		bot:    bot,
+		chatID: defaultChatID,
+	}
+}
+
+func (n *Notifier) Notify(obj interface{}, args ...interface{}) {
+	n.notify(n.chatID, obj, args...)
+}
+
+func (n *Notifier) NotifyTo(channel string, obj interface{}, args ...interface{}) {
+	chatID := n.chatID
+	if channel != "" {
+		if id, err := strconv.ParseInt(channel, 10, 64); err == nil {
+			chatID = id
+		} else {
+			log.WithError(err).Errorf("telegram notify: invalid chat id %q, falling back to default", channel)
+		}
+	}
+	n.notify(chatID, obj, args...)
+}
+
+func (n *Notifier) notify(chatID int64, obj interface{}, args ...interface{}) {
+	text, ok := obj.(string)
+	if !ok {
+		text = fmt.Sprintf("%+v", obj)
+	}
+
+	msg := tgbotapi.NewMessage(chatID, text)
+	if _, err := n.bot.Send(msg); err != nil {
+		log.WithError(err).Error("telegram notify error")
+	}
+}
+
+func (n *Notifier) SendPhoto(buffer *bytes.Buffer) error {
+	photo := tgbotapi.NewPhoto(n.chatID, tgbotapi.FileReader{
+		Name:   "chart.png",
+		Reader: buffer,
+	})
+	_, err := n.bot.Send(photo)
+	return err
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/xitongsys/parquet-go-source/local"
+	"github.com/xitongsys/parquet-go/reader"
+	"github.com/xitongsys/parquet-go/writer"
+
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+// parquetTrade mirrors types.Trade with the struct tags parquet-go needs to
+// pick a column encoding for each field.
+type parquetTrade struct {
+	ID          int64   `parquet:"name=id, type=INT64"`
+	Exchange    string  `parquet:"name=exchange, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Price       float64 `parquet:"name=price, type=DOUBLE"`
+	Volume      float64 `parquet:"name=volume, type=DOUBLE"`
+	Side        string  `parquet:"name=side, type=BYTE_ARRAY, convertedtype=UTF8"`
+	IsBuyer     bool    `parquet:"name=is_buyer, type=BOOLEAN"`
+	IsMaker     bool    `parquet:"name=is_maker, type=BOOLEAN"`
+	Time        int64   `parquet:"name=time, type=INT64"`
+	Symbol      string  `parquet:"name=symbol, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Fee         float64 `parquet:"name=fee, type=DOUBLE"`
+	FeeCurrency string  `parquet:"name=fee_currency, type=BYTE_ARRAY, convertedtype=UTF8"`
+}
+
+func toParquetTrade(trade types.Trade) parquetTrade {
+	return parquetTrade{
+		ID:          trade.ID,
+		Exchange:    string(trade.Exchange),
+		Price:       trade.Price,
+		Volume:      trade.Volume,
+		Side:        trade.Side,
+		IsBuyer:     trade.IsBuyer,
+		IsMaker:     trade.IsMaker,
+		Time:        trade.Time.UnixNano(),
+		Symbol:      trade.Symbol,
+		Fee:         trade.Fee,
+		FeeCurrency: trade.FeeCurrency,
+	}
+}
+
+// ParquetTradeExporter writes trades to columnar Parquet files on disk, one
+// file per symbol per day, so the backtester and external analytics tools
+// can read a trade history without hitting the OLTP database.
+type ParquetTradeExporter struct {
+	// Directory is the root directory files are written under, as
+	// <Directory>/<symbol>/<YYYY-MM-DD>.parquet.
+	Directory string
+}
+
+// NewParquetTradeExporter creates an exporter rooted at directory.
+func NewParquetTradeExporter(directory string) *ParquetTradeExporter {
+	return &ParquetTradeExporter{Directory: directory}
+}
+
+// tradeKey identifies a trade row uniquely across exchanges. Trade IDs are
+// per-exchange sequence numbers, so two exchanges trading the same symbol
+// can hand out colliding IDs -- the exchange must be part of the key or
+// their rows silently overwrite each other in the shared daily file.
+type tradeKey struct {
+	Exchange string
+	ID       int64
+}
+
+// Export writes trades to the daily Parquet file for symbol. If a file
+// already exists for that day (e.g. from an earlier incremental sync run),
+// its rows are read back first and merged with the new trades, deduplicated
+// by (exchange, trade ID), since Parquet files can't be appended to in place.
+func (e *ParquetTradeExporter) Export(symbol string, day string, trades []types.Trade) error {
+	if len(trades) == 0 {
+		return nil
+	}
+
+	dir := filepath.Join(e.Directory, symbol)
+	if err := os.MkdirAll(dir, 0777); err != nil {
+		return fmt.Errorf("parquet export directory error for %s: %w", dir, err)
+	}
+	path := filepath.Join(dir, day+".parquet")
+
+	existing, err := readParquetTrades(path)
+	if err != nil {
+		return fmt.Errorf("parquet read error for %s: %w", path, err)
+	}
+
+	merged := make(map[tradeKey]parquetTrade, len(existing)+len(trades))
+	for _, row := range existing {
+		merged[tradeKey{Exchange: row.Exchange, ID: row.ID}] = row
+	}
+	for _, trade := range trades {
+		row := toParquetTrade(trade)
+		merged[tradeKey{Exchange: row.Exchange, ID: row.ID}] = row
+	}
+
+	fw, err := local.NewLocalFileWriter(path)
+	if err != nil {
+		return fmt.Errorf("parquet file open error for %s: %w", path, err)
+	}
+	defer fw.Close()
+
+	pw, err := writer.NewParquetWriter(fw, new(parquetTrade), 4)
+	if err != nil {
+		return fmt.Errorf("parquet writer init error for %s: %w", path, err)
+	}
+
+	for _, row := range merged {
+		if err := pw.Write(row); err != nil {
+			return fmt.Errorf("parquet write error for %s: %w", path, err)
+		}
+	}
+
+	if err := pw.WriteStop(); err != nil {
+		return fmt.Errorf("parquet finalize error for %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// readParquetTrades returns the rows already present in path, or nil if the
+// file doesn't exist yet.
+func readParquetTrades(path string) ([]parquetTrade, error) {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	fr, err := local.NewLocalFileReader(path)
+	if err != nil {
+		return nil, err
+	}
+	defer fr.Close()
+
+	pr, err := reader.NewParquetReader(fr, new(parquetTrade), 4)
+	if err != nil {
+		return nil, err
+	}
+	defer pr.ReadStop()
+
+	rows := make([]parquetTrade, pr.GetNumRows())
+	if err := pr.Read(&rows); err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"github.com/jmoiron/sqlx"
+
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+// SQLTradeStore is the original TradeStore backend: a single `trades` table
+// in whatever SQL database sqlx.DB is connected to (sqlite3/mysql today).
+type SQLTradeStore struct {
+	DB *sqlx.DB
+}
+
+// NewSQLTradeStore wraps an existing *sqlx.DB connection as a TradeStore.
+func NewSQLTradeStore(db *sqlx.DB) *SQLTradeStore {
+	return &SQLTradeStore{DB: db}
+}
+
+func (s *SQLTradeStore) Insert(trade types.Trade) error {
+	_, err := s.DB.NamedExec(`
+		INSERT INTO trades (id, exchange, symbol, price, volume, side, is_buyer, is_maker, fee, fee_currency, traded_at)
+		VALUES (:id, :exchange, :symbol, :price, :volume, :side, :is_buyer, :is_maker, :fee, :fee_currency, :traded_at)
+	`, namedTrade(trade))
+	return err
+}
+
+func (s *SQLTradeStore) Query(exchangeName types.ExchangeName, symbol string) (trades []types.Trade, err error) {
+	err = s.DB.Select(&trades, `
+		SELECT * FROM trades WHERE exchange = ? AND symbol = ? ORDER BY traded_at ASC
+	`, exchangeName, symbol)
+	return trades, err
+}
+
+func (s *SQLTradeStore) QueryForTradingFeeCurrency(exchangeName types.ExchangeName, symbol, feeCurrency string) (trades []types.Trade, err error) {
+	err = s.DB.Select(&trades, `
+		SELECT * FROM trades WHERE exchange = ? AND symbol = ? AND fee_currency = ? ORDER BY traded_at ASC
+	`, exchangeName, symbol, feeCurrency)
+	return trades, err
+}
+
+// namedTrade adapts types.Trade's field names to the trades table's
+// snake_case columns for use with sqlx's named-parameter exec.
+func namedTrade(trade types.Trade) map[string]interface{} {
+	return map[string]interface{}{
+		"id":           trade.ID,
+		"exchange":     trade.Exchange,
+		"symbol":       trade.Symbol,
+		"price":        trade.Price,
+		"volume":       trade.Volume,
+		"side":         trade.Side,
+		"is_buyer":     trade.IsBuyer,
+		"is_maker":     trade.IsMaker,
+		"fee":          trade.Fee,
+		"fee_currency": trade.FeeCurrency,
+		"traded_at":    trade.Time,
+	}
+}
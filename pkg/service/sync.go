@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+// SyncService pulls trades from an exchange into a TradeService-backed
+// store, and optionally mirrors each sync run's trades out to a
+// ParquetTradeExporter so the backtester or external analytics tools can
+// consume them without hitting the OLTP database.
+type SyncService struct {
+	TradeService *TradeService
+
+	// ParquetExporter, when set, receives an incremental export of the
+	// trades pulled by the most recent SyncTrades call.
+	ParquetExporter *ParquetTradeExporter
+}
+
+// SyncTrades fetches trades for symbol from exchange since startTime,
+// inserts any new ones into TradeService, and, if a ParquetExporter is
+// configured, exports them grouped by day so the Parquet files stay
+// incrementally up to date with the OLTP database.
+func (s *SyncService) SyncTrades(ctx context.Context, exchange types.Exchange, symbol string, startTime time.Time) error {
+	trades, err := exchange.QueryTrades(ctx, symbol, &types.TradeQueryOptions{StartTime: &startTime})
+	if err != nil {
+		return err
+	}
+
+	for _, trade := range trades {
+		if err := s.TradeService.Insert(trade); err != nil {
+			return err
+		}
+	}
+
+	if s.ParquetExporter != nil {
+		if err := s.exportToParquet(symbol, trades); err != nil {
+			log.WithError(err).Errorf("parquet export error for %s", symbol)
+		}
+	}
+
+	return nil
+}
+
+// exportToParquet groups trades by UTC day and writes each group to its
+// daily Parquet file.
+func (s *SyncService) exportToParquet(symbol string, trades []types.Trade) error {
+	byDay := make(map[string][]types.Trade)
+	for _, trade := range trades {
+		day := trade.Time.UTC().Format("2006-01-02")
+		byDay[day] = append(byDay[day], trade)
+	}
+
+	for day, dayTrades := range byDay {
+		if err := s.ParquetExporter.Export(symbol, day, dayTrades); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
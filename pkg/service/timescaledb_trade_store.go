@@ -0,0 +1,82 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/c9s/bbgo/pkg/types"
+)
+
+// TimescaleDBTradeStore stores trades in a TimescaleDB hypertable
+// partitioned by traded_at, so range scans over large trade histories (the
+// kind the backtester and long-running PnL reports need) stay fast as the
+// table grows. It speaks the same SQL dialect as SQLTradeStore and only
+// differs in the table's creation/migration.
+type TimescaleDBTradeStore struct {
+	DB *sqlx.DB
+}
+
+// NewTimescaleDBTradeStore wraps a *sqlx.DB connected to a TimescaleDB
+// instance as a TradeStore. EnsureHypertable should be called once during
+// startup to create the table and register it as a hypertable.
+func NewTimescaleDBTradeStore(db *sqlx.DB) *TimescaleDBTradeStore {
+	return &TimescaleDBTradeStore{DB: db}
+}
+
+// EnsureHypertable creates the trades table if it doesn't exist yet and
+// registers it as a TimescaleDB hypertable partitioned by traded_at, which is
+// a no-op if it's already a hypertable.
+func (s *TimescaleDBTradeStore) EnsureHypertable() error {
+	if _, err := s.DB.Exec(`
+		CREATE TABLE IF NOT EXISTS trades (
+			id           BIGINT NOT NULL,
+			exchange     TEXT NOT NULL,
+			symbol       TEXT NOT NULL,
+			price        DOUBLE PRECISION NOT NULL,
+			volume       DOUBLE PRECISION NOT NULL,
+			side         TEXT NOT NULL,
+			is_buyer     BOOLEAN NOT NULL,
+			is_maker     BOOLEAN NOT NULL,
+			fee          DOUBLE PRECISION NOT NULL,
+			fee_currency TEXT NOT NULL,
+			traded_at    TIMESTAMPTZ NOT NULL,
+			PRIMARY KEY (id, traded_at)
+		)
+	`); err != nil {
+		return fmt.Errorf("create trades table error: %w", err)
+	}
+
+	if _, err := s.DB.Exec(`SELECT create_hypertable('trades', 'traded_at', if_not_exists => TRUE)`); err != nil {
+		return fmt.Errorf("create_hypertable error: %w", err)
+	}
+
+	return nil
+}
+
+func (s *TimescaleDBTradeStore) Insert(trade types.Trade) error {
+	_, err := s.DB.NamedExec(`
+		INSERT INTO trades (id, exchange, symbol, price, volume, side, is_buyer, is_maker, fee, fee_currency, traded_at)
+		VALUES (:id, :exchange, :symbol, :price, :volume, :side, :is_buyer, :is_maker, :fee, :fee_currency, :traded_at)
+	`, namedTrade(trade))
+	return err
+}
+
+// Query returns the full trade history for exchangeName/symbol, matching
+// SQLTradeStore's behavior -- range speed comes from the traded_at
+// hypertable partitioning (see EnsureHypertable), not from a fixed lookback
+// window, so switching backends doesn't silently change what callers like
+// Environment.PnLReport see.
+func (s *TimescaleDBTradeStore) Query(exchangeName types.ExchangeName, symbol string) (trades []types.Trade, err error) {
+	err = s.DB.Select(&trades, s.DB.Rebind(`
+		SELECT * FROM trades WHERE exchange = ? AND symbol = ? ORDER BY traded_at ASC
+	`), exchangeName, symbol)
+	return trades, err
+}
+
+func (s *TimescaleDBTradeStore) QueryForTradingFeeCurrency(exchangeName types.ExchangeName, symbol, feeCurrency string) (trades []types.Trade, err error) {
+	err = s.DB.Select(&trades, s.DB.Rebind(`
+		SELECT * FROM trades WHERE exchange = ? AND symbol = ? AND fee_currency = ? ORDER BY traded_at ASC
+	`), exchangeName, symbol, feeCurrency)
+	return trades, err
+}
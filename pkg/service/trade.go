@@ -0,0 +1,29 @@
+package service
+
+import "github.com/c9s/bbgo/pkg/types"
+
+// TradeService is the read/write facade used by the rest of bbgo (trade
+// sync, strategies, the backtester) to get at persisted trades. It delegates
+// to a pluggable TradeStore so the same callers work unchanged whether
+// trades live in the OLTP database or a TimescaleDB hypertable. Parquet
+// exports cannot be read back through it.
+type TradeService struct {
+	Store TradeStore
+}
+
+// NewTradeService wraps store in a TradeService.
+func NewTradeService(store TradeStore) *TradeService {
+	return &TradeService{Store: store}
+}
+
+func (s *TradeService) Insert(trade types.Trade) error {
+	return s.Store.Insert(trade)
+}
+
+func (s *TradeService) Query(exchangeName types.ExchangeName, symbol string) ([]types.Trade, error) {
+	return s.Store.Query(exchangeName, symbol)
+}
+
+func (s *TradeService) QueryForTradingFeeCurrency(exchangeName types.ExchangeName, symbol, feeCurrency string) ([]types.Trade, error) {
+	return s.Store.QueryForTradingFeeCurrency(exchangeName, symbol, feeCurrency)
+}
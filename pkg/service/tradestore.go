@@ -0,0 +1,14 @@
+package service
+
+import "github.com/c9s/bbgo/pkg/types"
+
+// TradeStore abstracts the backend used to persist and query trades, so
+// TradeService can sit on top of the existing SQL database (SQLTradeStore) or
+// a TimescaleDB hypertable for fast range scans (TimescaleDBTradeStore),
+// without callers needing to know which one is in use. ParquetTradeExporter
+// does not implement TradeStore; it only writes export files.
+type TradeStore interface {
+	Insert(trade types.Trade) error
+	Query(exchangeName types.ExchangeName, symbol string) ([]types.Trade, error)
+	QueryForTradingFeeCurrency(exchangeName types.ExchangeName, symbol, feeCurrency string) ([]types.Trade, error)
+}